@@ -0,0 +1,88 @@
+//go:build ignore
+
+// Filename: filewatcher-v10.go
+// Steps followed:
+//  1. Program takes --watch (comma-separated include globs) and --exclude
+//     (comma-separated exclude globs) instead of filewatcher-v9.go's single
+//     --path, e.g. --watch='./logs/**/*.log,./config/*.yaml'
+//     --exclude='**/.git/**,**/node_modules/**'.
+//  2. glob.NewValidator pre-validates the patterns, rejecting any whose
+//     static prefix doesn't exist, then Dirs() gives the minimal set of
+//     directories that actually need to be registered with the backend.
+//  3. pkg/filewatch is told to skip hidden directories and common
+//     build/vendor dirs while walking those trees (glob.SkipDir), same as
+//     the walkAndWatch example.
+//  4. Every event is re-matched against the include/exclude patterns
+//     before being printed, so a Create inside a watched directory that
+//     doesn't match any pattern (e.g. a stray .tmp file next to the .log
+//     files a pattern asked for) is silently dropped.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hshekhar0/myGoJourney/pkg/filewatch"
+	"github.com/hshekhar0/myGoJourney/pkg/glob"
+)
+
+func main() {
+	watchSpec := flag.String("watch", "./**/*", "Comma-separated list of glob patterns to watch")
+	excludeSpec := flag.String("exclude", "**/.git/**,**/node_modules/**,**/vendor/**,**/build/**,**/dist/**", "Comma-separated list of glob patterns to exclude")
+	backend := flag.String("backend", "auto", "Backend to use: auto, fsnotify, or poll")
+	flag.Parse()
+
+	validator, err := glob.NewValidator(splitSpec(*watchSpec), splitSpec(*excludeSpec))
+	if err != nil {
+		log.Fatalf("Invalid watch spec: %v", err)
+	}
+
+	w, err := filewatch.New(filewatch.Options{
+		Backend: filewatch.Backend(*backend),
+		SkipDir: glob.SkipDir,
+	})
+	if err != nil {
+		log.Fatalf("Could not create watcher: %v", err)
+	}
+	defer w.Close()
+
+	dirs := validator.Dirs()
+	for _, dir := range dirs {
+		if err := w.AddRecursive(dir); err != nil {
+			log.Fatalf("Error adding directory to watcher: %v", err)
+		}
+	}
+	fmt.Printf("Watching %d director%s matching: %s\n", len(dirs), plural(len(dirs)), *watchSpec)
+
+	for {
+		select {
+		case event := <-w.Events():
+			if event.IsDir || !validator.Match(event.Path) {
+				continue
+			}
+			fmt.Printf("%s: %s\n", event.Op, event.Path)
+		case err := <-w.Errors():
+			log.Println("Error:", err)
+		}
+	}
+}
+
+func splitSpec(spec string) []string {
+	var patterns []string
+	for _, p := range strings.Split(spec, ",") {
+		p = strings.TrimSpace(p)
+		if p != "" {
+			patterns = append(patterns, p)
+		}
+	}
+	return patterns
+}
+
+func plural(n int) string {
+	if n == 1 {
+		return "y"
+	}
+	return "ies"
+}
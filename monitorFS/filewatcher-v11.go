@@ -0,0 +1,97 @@
+//go:build ignore
+
+// Filename: filewatcher-v11.go
+// Steps followed:
+//  1. Builds on filewatcher-v10.go's --watch/--exclude glob spec.
+//  2. Adds --policy, a YAML file of pkg/policy rules, replacing
+//     filewatcher-v8.go's hard-coded handleDisallowedFile (which only
+//     ever deleted a disallowed extension) with configurable
+//     log/notify/quarantine/delete/exec actions per rule.
+//  3. --dry-run runs the engine in DryRun mode: every rule match is
+//     logged but no file is touched, so a new policy can be sanity
+//     checked against a live directory before it's trusted.
+//  4. Only Create events are run through the policy engine - a file
+//     that was already allowed in doesn't need re-checking on every
+//     subsequent write.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hshekhar0/myGoJourney/pkg/filewatch"
+	"github.com/hshekhar0/myGoJourney/pkg/glob"
+	"github.com/hshekhar0/myGoJourney/pkg/policy"
+	"github.com/sirupsen/logrus"
+)
+
+func main() {
+	watchSpec := flag.String("watch", "./**/*", "Comma-separated list of glob patterns to watch")
+	excludeSpec := flag.String("exclude", "**/.git/**,**/node_modules/**,**/vendor/**,**/build/**,**/dist/**", "Comma-separated list of glob patterns to exclude")
+	backend := flag.String("backend", "auto", "Backend to use: auto, fsnotify, or poll")
+	policyPath := flag.String("policy", "", "Path to a YAML policy file")
+	dryRun := flag.Bool("dry-run", false, "Log what the policy would do without touching files")
+	flag.Parse()
+
+	if *policyPath == "" {
+		log.Fatal("--policy is required")
+	}
+
+	logger := logrus.New()
+
+	pol, err := policy.Load(*policyPath)
+	if err != nil {
+		log.Fatalf("Could not load policy: %v", err)
+	}
+	engine := policy.NewEngine(pol, logger)
+	engine.DryRun = *dryRun
+
+	validator, err := glob.NewValidator(splitSpec(*watchSpec), splitSpec(*excludeSpec))
+	if err != nil {
+		log.Fatalf("Invalid watch spec: %v", err)
+	}
+
+	w, err := filewatch.New(filewatch.Options{
+		Backend: filewatch.Backend(*backend),
+		SkipDir: glob.SkipDir,
+	})
+	if err != nil {
+		log.Fatalf("Could not create watcher: %v", err)
+	}
+	defer w.Close()
+
+	for _, dir := range validator.Dirs() {
+		if err := w.AddRecursive(dir); err != nil {
+			log.Fatalf("Error adding directory to watcher: %v", err)
+		}
+	}
+
+	fmt.Printf("Watching: %s (policy=%s, dry-run=%v)\n", *watchSpec, *policyPath, *dryRun)
+
+	for {
+		select {
+		case event := <-w.Events():
+			if event.IsDir || event.Op&filewatch.Create == 0 || !validator.Match(event.Path) {
+				continue
+			}
+			if err := engine.Handle(event.Path); err != nil {
+				logger.Errorf("Failed to apply policy to %s: %v", event.Path, err)
+			}
+		case err := <-w.Errors():
+			logger.Errorf("Watcher error: %v", err)
+		}
+	}
+}
+
+func splitSpec(spec string) []string {
+	var patterns []string
+	for _, p := range strings.Split(spec, ",") {
+		p = strings.TrimSpace(p)
+		if p != "" {
+			patterns = append(patterns, p)
+		}
+	}
+	return patterns
+}
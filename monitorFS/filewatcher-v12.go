@@ -0,0 +1,176 @@
+//go:build ignore
+
+// Filename: filewatcher-v12.go
+// Steps followed:
+//  1. Builds on filewatcher-v11.go's --watch/--exclude/--policy/--dry-run.
+//  2. Adds --emit=json, which writes one pkg/emit.Event per line to
+//     stdout (or a named pipe given as --emit-target) instead of the
+//     plain "op: path" lines filewatcher-v9.go/v10.go print.
+//  3. Adds --metrics-addr, which serves Prometheus counters for events
+//     per op, per-extension counts, policy actions taken, and watcher
+//     queue overflow errors, plus a gauge of watched directories - this
+//     replaces the ad-hoc logrus.Infof calls filewatcher-v8.go's
+//     processEvents made with something systemd/k8s can alert on.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/hshekhar0/myGoJourney/pkg/emit"
+	"github.com/hshekhar0/myGoJourney/pkg/filewatch"
+	"github.com/hshekhar0/myGoJourney/pkg/glob"
+	"github.com/hshekhar0/myGoJourney/pkg/metrics"
+	"github.com/hshekhar0/myGoJourney/pkg/policy"
+	"github.com/sirupsen/logrus"
+)
+
+func main() {
+	watchSpec := flag.String("watch", "./**/*", "Comma-separated list of glob patterns to watch")
+	excludeSpec := flag.String("exclude", "**/.git/**,**/node_modules/**,**/vendor/**,**/build/**,**/dist/**", "Comma-separated list of glob patterns to exclude")
+	backend := flag.String("backend", "auto", "Backend to use: auto, fsnotify, or poll")
+	policyPath := flag.String("policy", "", "Path to a YAML policy file")
+	dryRun := flag.Bool("dry-run", false, "Log what the policy would do without touching files")
+	emitMode := flag.String("emit", "", "Set to 'json' to write newline-delimited JSON events instead of plain text")
+	emitTarget := flag.String("emit-target", "-", "Where --emit=json writes to: '-' for stdout, or a path to a named pipe")
+	metricsAddr := flag.String("metrics-addr", "", "Address to serve Prometheus metrics on, e.g. :9090 (disabled if empty)")
+	flag.Parse()
+
+	if *policyPath == "" {
+		log.Fatal("--policy is required")
+	}
+
+	logger := logrus.New()
+
+	pol, err := policy.Load(*policyPath)
+	if err != nil {
+		log.Fatalf("Could not load policy: %v", err)
+	}
+	engine := policy.NewEngine(pol, logger)
+	engine.DryRun = *dryRun
+
+	validator, err := glob.NewValidator(splitSpec(*watchSpec), splitSpec(*excludeSpec))
+	if err != nil {
+		log.Fatalf("Invalid watch spec: %v", err)
+	}
+
+	m := metrics.New()
+	if *metricsAddr != "" {
+		go func() {
+			if err := http.ListenAndServe(*metricsAddr, m.Handler()); err != nil {
+				logger.Errorf("metrics server stopped: %v", err)
+			}
+		}()
+	}
+
+	var jsonWriter *emit.Writer
+	if *emitMode == "json" {
+		target, err := emit.Open(*emitTarget)
+		if err != nil {
+			log.Fatalf("Could not open --emit-target: %v", err)
+		}
+		defer target.Close()
+		jsonWriter = emit.NewWriter(target)
+	}
+
+	w, err := filewatch.New(filewatch.Options{
+		Backend: filewatch.Backend(*backend),
+		SkipDir: glob.SkipDir,
+	})
+	if err != nil {
+		log.Fatalf("Could not create watcher: %v", err)
+	}
+	defer w.Close()
+
+	dirs := validator.Dirs()
+	for _, dir := range dirs {
+		if err := w.AddRecursive(dir); err != nil {
+			log.Fatalf("Error adding directory to watcher: %v", err)
+		}
+	}
+	m.SetWatchedDirs(len(dirs))
+
+	for {
+		select {
+		case event := <-w.Events():
+			if !validator.Match(event.Path) {
+				continue
+			}
+
+			ext := filepath.Ext(event.Path)
+			m.ObserveEvent(event.Op.String(), ext)
+
+			var ruleMatched string
+			if !event.IsDir && event.Op&filewatch.Create != 0 {
+				if rule, err := pol.Match(event.Path); err != nil {
+					logger.Errorf("Failed to match policy for %s: %v", event.Path, err)
+				} else if rule != nil {
+					ruleMatched = rule.Name
+					if err := engine.Handle(event.Path); err != nil {
+						logger.Errorf("Failed to apply policy to %s: %v", event.Path, err)
+					} else if !engine.DryRun {
+						m.ObservePolicyAction(string(rule.Action))
+					}
+				}
+			}
+
+			reportEvent(jsonWriter, logger, event, ruleMatched)
+
+		case err := <-w.Errors():
+			if err != nil && fsnotifyIsOverflow(err) {
+				m.IncQueueOverflow()
+			}
+			logger.Errorf("Watcher error: %v", err)
+		}
+	}
+}
+
+// reportEvent writes event either as NDJSON (when jsonWriter is set) or
+// as the plain "op: path" line earlier versions printed.
+func reportEvent(jsonWriter *emit.Writer, logger *logrus.Logger, event filewatch.Event, ruleMatched string) {
+	if jsonWriter == nil {
+		fmt.Printf("%s: %s\n", event.Op, event.Path)
+		return
+	}
+
+	var size int64
+	if info, err := os.Stat(event.Path); err == nil {
+		size = info.Size()
+	}
+
+	err := jsonWriter.Write(emit.Event{
+		TS:          time.Now().UTC(),
+		Op:          event.Op.String(),
+		Path:        event.Path,
+		OldPath:     event.OldPath,
+		Size:        size,
+		IsDir:       event.IsDir,
+		RuleMatched: ruleMatched,
+	})
+	if err != nil {
+		logger.Errorf("Failed to write JSON event: %v", err)
+	}
+}
+
+func fsnotifyIsOverflow(err error) bool {
+	return errors.Is(err, fsnotify.ErrEventOverflow)
+}
+
+func splitSpec(spec string) []string {
+	var patterns []string
+	for _, p := range strings.Split(spec, ",") {
+		p = strings.TrimSpace(p)
+		if p != "" {
+			patterns = append(patterns, p)
+		}
+	}
+	return patterns
+}
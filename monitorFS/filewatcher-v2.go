@@ -1,3 +1,5 @@
+//go:build ignore
+
 /* Filename: filewatcher-v1.go
 Steps followed:
 1) Program takes `path to directory` as an argument.
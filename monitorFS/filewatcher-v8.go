@@ -1,3 +1,5 @@
+//go:build ignore
+
 /*
 This program uses the Logrus library for logging and the Watcher package for monitoring file system changes. It accepts the following command-line arguments:
 
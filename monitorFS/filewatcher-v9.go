@@ -0,0 +1,67 @@
+//go:build ignore
+
+/*
+	Filename: filewatcher-v9.go
+
+Steps followed:
+ 1. Program takes `path to directory` and `--backend` as arguments.
+ 2. It checks if the directory exists and is actually a directory, same
+    as filewatcher-v1.go / filewatcher-v2.go.
+ 3. Instead of talking to fsnotify or radovskyb/watcher directly like the
+    earlier versions did, it builds a pkg/filewatch.Watcher so the same
+    CLI works on top of either backend.
+ 4. --backend=auto (the default) tries fsnotify first and falls back to
+    polling automatically; --backend=fsnotify / --backend=poll force one.
+ 5. Prints every normalized event the same way regardless of backend.
+*/
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/hshekhar0/myGoJourney/pkg/filewatch"
+)
+
+func main() {
+	dirPath := flag.String("path", ".", "Directory to watch")
+	backend := flag.String("backend", "auto", "Backend to use: auto, fsnotify, or poll")
+	flag.Parse()
+
+	info, err := os.Stat(*dirPath)
+	if os.IsNotExist(err) {
+		log.Fatalf("Directory does not exist: %s", *dirPath)
+	}
+	if !info.IsDir() {
+		log.Fatalf("Provided path is not a directory: %s", *dirPath)
+	}
+
+	absPath, err := filepath.Abs(*dirPath)
+	if err != nil {
+		log.Fatalf("Could not determine absolute path: %v", err)
+	}
+
+	w, err := filewatch.New(filewatch.Options{Backend: filewatch.Backend(*backend)})
+	if err != nil {
+		log.Fatalf("Could not create watcher: %v", err)
+	}
+	defer w.Close()
+
+	if err := w.AddRecursive(absPath); err != nil {
+		log.Fatalf("Error adding directory to watcher: %v", err)
+	}
+
+	fmt.Printf("Watching directory: %s (backend=%s)\n", absPath, *backend)
+
+	for {
+		select {
+		case event := <-w.Events():
+			fmt.Printf("%s: %s\n", event.Op, event.Path)
+		case err := <-w.Errors():
+			log.Println("Error:", err)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+// Package emit writes filewatch events out as newline-delimited JSON so
+// downstream tools can consume the stream, the --emit=json counterpart
+// to the plain "op: path" lines filewatcher-v9.go/v10.go print.
+package emit
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// Event is one line of the NDJSON stream.
+type Event struct {
+	TS          time.Time `json:"ts"`
+	Op          string    `json:"op"`
+	Path        string    `json:"path"`
+	OldPath     string    `json:"old_path,omitempty"`
+	Size        int64     `json:"size"`
+	IsDir       bool      `json:"is_dir"`
+	RuleMatched string    `json:"rule_matched,omitempty"`
+}
+
+// Writer serializes Events to an underlying io.Writer as NDJSON, one
+// JSON object per line.
+type Writer struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewWriter wraps w.
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{w: w}
+}
+
+// Write marshals ev and appends it, newline-terminated, to the stream.
+func (jw *Writer) Write(ev Event) error {
+	line, err := json.Marshal(ev)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	jw.mu.Lock()
+	defer jw.mu.Unlock()
+	_, err = jw.w.Write(line)
+	return err
+}
+
+// Open resolves a --emit target into a writer: "" or "-" means stdout,
+// anything else is treated as the path to a named pipe the operator
+// created with mkfifo(1) (or a plain file) and is opened for writing.
+func Open(target string) (io.WriteCloser, error) {
+	if target == "" || target == "-" {
+		return nopCloser{os.Stdout}, nil
+	}
+	// O_WRONLY with no O_CREATE: target is expected to already exist,
+	// typically a FIFO the operator created with mkfifo(1).
+	f, err := os.OpenFile(target, os.O_WRONLY, 0)
+	if err != nil {
+		return nil, fmt.Errorf("emit: could not open %s for writing: %w", target, err)
+	}
+	return f, nil
+}
+
+type nopCloser struct{ io.Writer }
+
+func (nopCloser) Close() error { return nil }
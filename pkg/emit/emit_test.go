@@ -0,0 +1,65 @@
+package emit
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWriterWritesOneLinePerEvent(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+
+	if err := w.Write(Event{Op: "CREATE", Path: "a.txt"}); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if err := w.Write(Event{Op: "REMOVE", Path: "b.txt"}); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines, want 2: %q", len(lines), buf.String())
+	}
+
+	var ev Event
+	if err := json.Unmarshal([]byte(lines[0]), &ev); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if ev.Op != "CREATE" || ev.Path != "a.txt" {
+		t.Errorf("got %+v, want Op=CREATE Path=a.txt", ev)
+	}
+}
+
+func TestWriterOmitsEmptyOptionalFields(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+
+	if err := w.Write(Event{TS: time.Now(), Op: "CREATE", Path: "a.txt"}); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	if strings.Contains(buf.String(), "old_path") || strings.Contains(buf.String(), "rule_matched") {
+		t.Errorf("expected empty OldPath/RuleMatched to be omitted, got %s", buf.String())
+	}
+}
+
+func TestOpenStdoutAliases(t *testing.T) {
+	for _, target := range []string{"", "-"} {
+		w, err := Open(target)
+		if err != nil {
+			t.Fatalf("Open(%q): %v", target, err)
+		}
+		if err := w.Close(); err != nil {
+			t.Errorf("Close() for %q target = %v, want nil (stdout must not be closed)", target, err)
+		}
+	}
+}
+
+func TestOpenMissingPipe(t *testing.T) {
+	if _, err := Open("/nonexistent/path/to/a/fifo"); err == nil {
+		t.Error("Open on a nonexistent target = nil error, want error")
+	}
+}
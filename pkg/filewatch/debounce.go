@@ -0,0 +1,79 @@
+package filewatch
+
+import "time"
+
+// debouncedWatcher wraps another Watcher and coalesces repeated events
+// for the same path that arrive within window into one, absorbing
+// quirks like the double Write that Windows' ReadDirectoryChangesW
+// reports for a single save.
+type debouncedWatcher struct {
+	Watcher
+	events chan Event
+	done   chan struct{}
+	window time.Duration
+}
+
+func newDebouncedWatcher(w Watcher, window time.Duration) Watcher {
+	dw := &debouncedWatcher{
+		Watcher: w,
+		events:  make(chan Event),
+		done:    make(chan struct{}),
+		window:  window,
+	}
+	go dw.loop()
+	return dw
+}
+
+func (dw *debouncedWatcher) loop() {
+	pending := make(map[string]Event)
+	timers := make(map[string]*time.Timer)
+	flush := make(chan string)
+
+	defer func() {
+		for _, t := range timers {
+			t.Stop()
+		}
+	}()
+
+	for {
+		select {
+		case ev, ok := <-dw.Watcher.Events():
+			if !ok {
+				return
+			}
+			pending[ev.Path] = ev
+			if t, scheduled := timers[ev.Path]; scheduled {
+				t.Reset(dw.window)
+				continue
+			}
+			path := ev.Path
+			timers[path] = time.AfterFunc(dw.window, func() {
+				select {
+				case flush <- path:
+				case <-dw.done:
+				}
+			})
+		case path := <-flush:
+			ev, ok := pending[path]
+			if !ok {
+				continue
+			}
+			delete(pending, path)
+			delete(timers, path)
+			select {
+			case dw.events <- ev:
+			case <-dw.done:
+				return
+			}
+		case <-dw.done:
+			return
+		}
+	}
+}
+
+func (dw *debouncedWatcher) Events() <-chan Event { return dw.events }
+
+func (dw *debouncedWatcher) Close() error {
+	close(dw.done)
+	return dw.Watcher.Close()
+}
@@ -0,0 +1,81 @@
+package filewatch
+
+import (
+	"testing"
+	"time"
+)
+
+// fakeWatcher is a minimal Watcher double that lets a test control
+// exactly what events/errors the debounce wrapper sees, without
+// depending on a real backend.
+type fakeWatcher struct {
+	events chan Event
+	errors chan error
+}
+
+func newFakeWatcher() *fakeWatcher {
+	return &fakeWatcher{events: make(chan Event), errors: make(chan error)}
+}
+
+func (fw *fakeWatcher) Events() <-chan Event         { return fw.events }
+func (fw *fakeWatcher) Errors() <-chan error         { return fw.errors }
+func (fw *fakeWatcher) Add(string) error             { return nil }
+func (fw *fakeWatcher) AddRecursive(string) error    { return nil }
+func (fw *fakeWatcher) Remove(string) error          { return nil }
+func (fw *fakeWatcher) RemoveRecursive(string) error { return nil }
+func (fw *fakeWatcher) Close() error                 { close(fw.events); return nil }
+
+// TestDebouncedWatcher_CoalescesRepeatedWrites checks that several
+// Write events for the same path arriving inside the debounce window
+// collapse into the single most recent event, the behavior that
+// absorbs Windows' double-Write-per-save quirk.
+func TestDebouncedWatcher_CoalescesRepeatedWrites(t *testing.T) {
+	inner := newFakeWatcher()
+	window := 30 * time.Millisecond
+	dw := newDebouncedWatcher(inner, window)
+	defer dw.Close()
+
+	inner.events <- Event{Path: "a.txt", Op: Write}
+	inner.events <- Event{Path: "a.txt", Op: Write}
+	inner.events <- Event{Path: "a.txt", Op: Write | Chmod}
+
+	select {
+	case ev := <-dw.Events():
+		if ev.Path != "a.txt" || ev.Op != Write|Chmod {
+			t.Errorf("got %+v, want the last coalesced event (Write|Chmod)", ev)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for the coalesced event")
+	}
+
+	select {
+	case ev := <-dw.Events():
+		t.Fatalf("got a second event %+v, want exactly one coalesced event", ev)
+	case <-time.After(2 * window):
+	}
+}
+
+// TestDebouncedWatcher_DistinctPathsNotCoalesced checks that events for
+// different paths aren't folded together even when they arrive within
+// the same debounce window.
+func TestDebouncedWatcher_DistinctPathsNotCoalesced(t *testing.T) {
+	inner := newFakeWatcher()
+	dw := newDebouncedWatcher(inner, 30*time.Millisecond)
+	defer dw.Close()
+
+	inner.events <- Event{Path: "a.txt", Op: Write}
+	inner.events <- Event{Path: "b.txt", Op: Write}
+
+	seen := make(map[string]bool)
+	for i := 0; i < 2; i++ {
+		select {
+		case ev := <-dw.Events():
+			seen[ev.Path] = true
+		case <-time.After(2 * time.Second):
+			t.Fatalf("timed out waiting for event %d", i)
+		}
+	}
+	if !seen["a.txt"] || !seen["b.txt"] {
+		t.Errorf("saw paths %v, want both a.txt and b.txt", seen)
+	}
+}
@@ -0,0 +1,13 @@
+package filewatch
+
+import "errors"
+
+// ErrNotDirectory is returned by the fsnotify backend's AddRecursive when
+// the given path exists but isn't a directory. RemoveRecursive and the
+// poll backend do not check for it.
+var ErrNotDirectory = errors.New("filewatch: not a directory")
+
+// ErrRecursionUnsupported is the sentinel for a backend that can't watch
+// a tree recursively. None of this package's backends returns it: the
+// FEN stub fails in New with ErrFENUnsupported before any path is added.
+var ErrRecursionUnsupported = errors.New("filewatch: recursive watch not supported by this backend")
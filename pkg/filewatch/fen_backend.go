@@ -0,0 +1,16 @@
+package filewatch
+
+import "errors"
+
+// ErrFENUnsupported is returned by newFENWatcher on every platform,
+// illumos included: this package has no File Events Notification
+// implementation.
+var ErrFENUnsupported = errors.New("filewatch: FEN backend is not implemented")
+
+// newFENWatcher is a stub with no build constraint. It does not use
+// port_create(3C)/PORT_SOURCE_FEN the way fsnotify's fen.go does;
+// requesting BackendFEN fails on every platform instead of silently
+// degrading.
+func newFENWatcher(opts Options) (Watcher, error) {
+	return nil, ErrFENUnsupported
+}
@@ -0,0 +1,168 @@
+// Package filewatch unifies the two divergent filewatcher programs under
+// monitorFS/ (filewatcher-v1.go's radovskyb/watcher polling and
+// filewatcher-v8.go's fsnotify) behind a single Watcher interface.
+//
+// Callers get one set of event semantics regardless of which backend is
+// actually doing the watching, and can ask for a specific backend or let
+// New pick one and fall back automatically - following the same strategy
+// moby's pkg/filenotify uses to degrade from inotify to polling when the
+// native watcher can't be used (instance limits, NFS/FUSE mounts, ...).
+package filewatch
+
+import "time"
+
+// Op describes the kind of change a backend observed. It mirrors the
+// fsnotify operation taxonomy so callers already familiar with fsnotify
+// don't have to learn a second vocabulary.
+type Op uint32
+
+const (
+	Create Op = 1 << iota
+	Write
+	Remove
+	Rename
+	Chmod
+)
+
+// String renders the set of operations an Event carries, e.g. "CREATE|WRITE".
+func (op Op) String() string {
+	names := []struct {
+		op   Op
+		name string
+	}{
+		{Create, "CREATE"},
+		{Write, "WRITE"},
+		{Remove, "REMOVE"},
+		{Rename, "RENAME"},
+		{Chmod, "CHMOD"},
+	}
+
+	s := ""
+	for _, n := range names {
+		if op&n.op == 0 {
+			continue
+		}
+		if s != "" {
+			s += "|"
+		}
+		s += n.name
+	}
+	if s == "" {
+		return "UNKNOWN"
+	}
+	return s
+}
+
+// Event is the normalized shape every backend emits on its Events channel.
+type Event struct {
+	Path    string // path the event happened to
+	OldPath string // previous path, set on Rename
+	Op      Op
+	IsDir   bool
+}
+
+// Backend selects which implementation New should construct.
+type Backend string
+
+const (
+	// BackendAuto tries the native event-driven watcher first and falls
+	// back to BackendPoll when it can't be used.
+	BackendAuto Backend = "auto"
+	// BackendFsnotify uses fsnotify (inotify/kqueue/ReadDirectoryChangesW).
+	BackendFsnotify Backend = "fsnotify"
+	// BackendPoll uses radovskyb/watcher, which polls the filesystem on
+	// an interval and therefore works on NFS/FUSE mounts and other
+	// filesystems that don't support native change notifications.
+	BackendPoll Backend = "poll"
+	// BackendFEN selects illumos's File Events Notification facility,
+	// which is only a stub here: New returns ErrFENUnsupported for it.
+	BackendFEN Backend = "fen"
+)
+
+// Options configures New.
+type Options struct {
+	// Backend picks the implementation. Defaults to BackendAuto.
+	Backend Backend
+	// PollInterval is how often the poll backend rescans watched paths.
+	// Defaults to 100ms, matching filewatcher-v1.go.
+	PollInterval time.Duration
+	// DebounceWindow, when non-zero, coalesces repeated events for the
+	// same path that arrive within the window into a single event. This
+	// absorbs quirks like Windows reporting a Write twice for one save.
+	DebounceWindow time.Duration
+	// SkipDir, when set, is consulted with a directory's base name
+	// while AddRecursive walks a tree (and while auto-registering new
+	// subdirectories); returning true keeps that directory - and
+	// everything under it - out of the watch set. glob.SkipDir provides
+	// the usual hidden/build/vendor defaults.
+	SkipDir func(name string) bool
+}
+
+// Watcher is the common surface every backend implements.
+type Watcher interface {
+	// Events returns the channel of normalized filesystem events.
+	Events() <-chan Event
+	// Errors returns the channel of backend errors.
+	Errors() <-chan error
+	// Add starts watching path, which must be a directory.
+	Add(path string) error
+	// AddRecursive watches path and every directory beneath it.
+	AddRecursive(path string) error
+	// Remove stops watching path.
+	Remove(path string) error
+	// RemoveRecursive stops watching path and every directory beneath it.
+	RemoveRecursive(path string) error
+	// Close stops the watcher and releases its resources.
+	Close() error
+}
+
+// New constructs a Watcher using the requested backend. With
+// BackendAuto (the default) it tries the native event-driven watcher
+// first and degrades to the polling backend when the native watcher
+// can't be created, e.g. because the platform's inotify/kqueue instance
+// limit has been hit.
+func New(opts Options) (Watcher, error) {
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = 100 * time.Millisecond
+	}
+
+	w, err := newBackend(opts)
+	if err != nil {
+		return nil, err
+	}
+	if opts.DebounceWindow > 0 {
+		w = newDebouncedWatcher(w, opts.DebounceWindow)
+	}
+	return w, nil
+}
+
+func newBackend(opts Options) (Watcher, error) {
+	switch opts.Backend {
+	case BackendPoll:
+		return newPollWatcher(opts)
+	case BackendFsnotify:
+		return newFsnotifyWatcher(opts)
+	case BackendFEN:
+		return newFENWatcher(opts)
+	case "", BackendAuto:
+		w, err := newFsnotifyWatcher(opts)
+		if err == nil {
+			return w, nil
+		}
+		if !shouldFallBackToPoll(err) {
+			return nil, err
+		}
+		return newPollWatcher(opts)
+	default:
+		return nil, &ErrUnknownBackend{Backend: opts.Backend}
+	}
+}
+
+// ErrUnknownBackend is returned by New when opts.Backend isn't recognized.
+type ErrUnknownBackend struct {
+	Backend Backend
+}
+
+func (e *ErrUnknownBackend) Error() string {
+	return "filewatch: unknown backend " + string(e.Backend)
+}
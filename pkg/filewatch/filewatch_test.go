@@ -0,0 +1,30 @@
+package filewatch
+
+import "testing"
+
+func TestOpString(t *testing.T) {
+	cases := []struct {
+		op   Op
+		want string
+	}{
+		{Create, "CREATE"},
+		{Create | Write, "CREATE|WRITE"},
+		{Rename, "RENAME"},
+		{0, "UNKNOWN"},
+	}
+	for _, c := range cases {
+		if got := c.op.String(); got != c.want {
+			t.Errorf("Op(%d).String() = %q, want %q", c.op, got, c.want)
+		}
+	}
+}
+
+func TestNewUnknownBackend(t *testing.T) {
+	_, err := New(Options{Backend: "bogus"})
+	if err == nil {
+		t.Fatal("New with unknown backend = nil error, want error")
+	}
+	if _, ok := err.(*ErrUnknownBackend); !ok {
+		t.Errorf("New err = %T, want *ErrUnknownBackend", err)
+	}
+}
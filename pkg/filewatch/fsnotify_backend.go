@@ -0,0 +1,154 @@
+package filewatch
+
+import (
+	"errors"
+	"os"
+	"runtime"
+	"sync"
+	"syscall"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// fsnotifyWatcher adapts *fsnotify.Watcher to the Watcher interface,
+// translating fsnotify.Event into the package's normalized Event.
+type fsnotifyWatcher struct {
+	w      *fsnotify.Watcher
+	events chan Event
+	errors chan error
+	done   chan struct{}
+
+	// recursiveRoots tracks the paths added via AddRecursive so that a
+	// Create event for a new subdirectory under one of them can be
+	// registered (and backfilled) automatically. Protected by mu.
+	mu             sync.Mutex
+	recursiveRoots []string
+
+	rename  renameCoalescer
+	skipDir func(name string) bool
+}
+
+func newFsnotifyWatcher(opts Options) (Watcher, error) {
+	w, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+
+	fw := &fsnotifyWatcher{
+		w:       w,
+		events:  make(chan Event),
+		errors:  make(chan error),
+		done:    make(chan struct{}),
+		skipDir: opts.SkipDir,
+	}
+	fw.rename = newRenameCoalescer(fw.emit)
+	go fw.loop()
+	return fw, nil
+}
+
+func (fw *fsnotifyWatcher) loop() {
+	for {
+		select {
+		case ev, ok := <-fw.w.Events:
+			if !ok {
+				return
+			}
+			fw.handleRawEvent(ev)
+		case err, ok := <-fw.w.Errors:
+			if !ok {
+				return
+			}
+			select {
+			case fw.errors <- err:
+			case <-fw.done:
+				return
+			}
+		case <-fw.done:
+			return
+		}
+	}
+}
+
+func translateFsnotifyOp(op fsnotify.Op) Op {
+	var out Op
+	if op&fsnotify.Create != 0 {
+		out |= Create
+	}
+	if op&fsnotify.Write != 0 {
+		out |= Write
+	}
+	if op&fsnotify.Remove != 0 {
+		out |= Remove
+	}
+	if op&fsnotify.Rename != 0 {
+		out |= Rename
+	}
+	if op&fsnotify.Chmod != 0 {
+		out |= Chmod
+	}
+	return out
+}
+
+func (fw *fsnotifyWatcher) Events() <-chan Event { return fw.events }
+func (fw *fsnotifyWatcher) Errors() <-chan error { return fw.errors }
+
+func (fw *fsnotifyWatcher) Add(path string) error {
+	return fw.w.Add(path)
+}
+
+func (fw *fsnotifyWatcher) Remove(path string) error {
+	return fw.w.Remove(path)
+}
+
+func (fw *fsnotifyWatcher) Close() error {
+	close(fw.done)
+	fw.rename.stop()
+	return fw.w.Close()
+}
+
+// handleRawEvent translates a raw fsnotify.Event, closes the new-subdirectory
+// race described in filewatcher-v1.go's step 5, and coalesces kqueue's
+// delete-then-rename pair into a single Rename event before emitting.
+func (fw *fsnotifyWatcher) handleRawEvent(ev fsnotify.Event) {
+	info, statErr := os.Stat(ev.Name)
+	isDir := statErr == nil && info.IsDir()
+	op := translateFsnotifyOp(ev.Op)
+
+	if fw.rename.observe(ev.Name, op, isDir) {
+		// Folded into a pending Rename; nothing to emit yet.
+		return
+	}
+
+	if op&Create != 0 && isDir && fw.isUnderRecursiveRoot(ev.Name) {
+		fw.registerNewSubdir(ev.Name)
+	}
+
+	fw.emit(Event{Path: ev.Name, Op: op, IsDir: isDir})
+}
+
+func (fw *fsnotifyWatcher) emit(e Event) {
+	select {
+	case fw.events <- e:
+	case <-fw.done:
+	}
+}
+
+// shouldFallBackToPoll reports whether err indicates the native watcher
+// couldn't be created for a reason the polling backend can work around,
+// such as hitting the platform's inotify/kqueue instance limit. It is
+// consulted only when the watcher is created, before any path is added,
+// so a filesystem without change notifications (NFS, FUSE) does not
+// trigger the fallback.
+func shouldFallBackToPoll(err error) bool {
+	if errors.Is(err, syscall.ENOSPC) {
+		// Linux returns ENOSPC when /proc/sys/fs/inotify/max_user_instances
+		// or max_user_watches is exceeded.
+		return true
+	}
+	if errors.Is(err, syscall.EMFILE) || errors.Is(err, syscall.ENFILE) {
+		return true
+	}
+	// BSD/Darwin kqueue exhaustion surfaces as EMFILE/ENFILE above; leave
+	// room for other platform-specific "try the poller instead" errors.
+	return runtime.GOOS != "windows" && errors.Is(err, syscall.ENOSYS)
+}
@@ -0,0 +1,93 @@
+package filewatch
+
+import (
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// renameCoalesceWindow is how long we hold a Remove event before giving
+// up on pairing it with a same-directory Create and deciding it really
+// was a delete rather than half of a rename.
+const renameCoalesceWindow = 50 * time.Millisecond
+
+// renameCoalescer folds kqueue's "delete-then-rename" pattern - a Remove
+// for the old name immediately followed by a Create for the new one -
+// into a single Rename event, the same semantics inotify reports
+// directly. The pairing is applied on every platform: any Remove
+// followed within the window by a Create in the same directory is
+// reported as a Rename, including an unrelated delete and create.
+type renameCoalescer struct {
+	emit func(Event)
+
+	mu sync.Mutex
+	// pending is keyed by the removed path itself (not its directory),
+	// since a directory can have more than one Remove pending at once -
+	// keying by directory would let a second delete in the same
+	// directory silently overwrite and lose the first's pending entry.
+	pending map[string]*pendingRemove
+}
+
+type pendingRemove struct {
+	event Event
+	timer *time.Timer
+}
+
+func newRenameCoalescer(emit func(Event)) renameCoalescer {
+	return renameCoalescer{emit: emit, pending: make(map[string]*pendingRemove)}
+}
+
+// observe inspects an incoming event and returns true if it was
+// consumed (either queued as a pending Remove, or paired up with one
+// to become a Rename) rather than needing to be emitted as-is.
+func (rc *renameCoalescer) observe(path string, op Op, isDir bool) bool {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	if op&Remove != 0 {
+		ev := Event{Path: path, Op: Remove, IsDir: isDir}
+		pr := &pendingRemove{event: ev}
+		pr.timer = time.AfterFunc(renameCoalesceWindow, func() {
+			rc.flush(path)
+		})
+		rc.pending[path] = pr
+		return true
+	}
+
+	if op&Create != 0 {
+		dir := filepath.Dir(path)
+		for oldPath, pr := range rc.pending {
+			if filepath.Dir(oldPath) != dir {
+				continue
+			}
+			pr.timer.Stop()
+			delete(rc.pending, oldPath)
+			rc.emit(Event{Path: path, OldPath: oldPath, Op: Rename, IsDir: isDir})
+			return true
+		}
+	}
+
+	return false
+}
+
+// flush emits a pending Remove that never found its Create pair, i.e.
+// it really was a delete rather than a rename.
+func (rc *renameCoalescer) flush(path string) {
+	rc.mu.Lock()
+	pr, ok := rc.pending[path]
+	if ok {
+		delete(rc.pending, path)
+	}
+	rc.mu.Unlock()
+	if ok {
+		rc.emit(pr.event)
+	}
+}
+
+func (rc *renameCoalescer) stop() {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	for _, pr := range rc.pending {
+		pr.timer.Stop()
+	}
+}
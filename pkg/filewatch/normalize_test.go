@@ -0,0 +1,64 @@
+package filewatch
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestRenameCoalescerMultipleRemoves reproduces a silent-event-loss bug:
+// removing two files in the same directory within the coalesce window
+// used to key pending Removes by directory, so the second Remove
+// overwrote the first's pending entry and its delete was never emitted.
+func TestRenameCoalescerMultipleRemoves(t *testing.T) {
+	var mu sync.Mutex
+	var emitted []Event
+	rc := newRenameCoalescer(func(ev Event) {
+		mu.Lock()
+		emitted = append(emitted, ev)
+		mu.Unlock()
+	})
+
+	if consumed := rc.observe("dir/a.txt", Remove, false); !consumed {
+		t.Fatalf("observe(a.txt, Remove) = false, want true")
+	}
+	if consumed := rc.observe("dir/b.txt", Remove, false); !consumed {
+		t.Fatalf("observe(b.txt, Remove) = false, want true")
+	}
+
+	time.Sleep(2 * renameCoalesceWindow)
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(emitted) != 2 {
+		t.Fatalf("got %d emitted events, want 2: %+v", len(emitted), emitted)
+	}
+	paths := map[string]bool{emitted[0].Path: true, emitted[1].Path: true}
+	if !paths["dir/a.txt"] || !paths["dir/b.txt"] {
+		t.Errorf("emitted paths = %v, want dir/a.txt and dir/b.txt", paths)
+	}
+}
+
+// TestRenameCoalescerPairsCreate checks that a Remove immediately
+// followed by a same-directory Create still coalesces into a Rename,
+// now that pending is keyed by path instead of directory.
+func TestRenameCoalescerPairsCreate(t *testing.T) {
+	var emitted []Event
+	rc := newRenameCoalescer(func(ev Event) {
+		emitted = append(emitted, ev)
+	})
+
+	rc.observe("dir/old.txt", Remove, false)
+	consumed := rc.observe("dir/new.txt", Create, false)
+	if !consumed {
+		t.Fatalf("observe(new.txt, Create) = false, want true")
+	}
+
+	if len(emitted) != 1 {
+		t.Fatalf("got %d emitted events, want 1: %+v", len(emitted), emitted)
+	}
+	got := emitted[0]
+	if got.Op != Rename || got.Path != "dir/new.txt" || got.OldPath != "dir/old.txt" {
+		t.Errorf("emitted = %+v, want Rename dir/old.txt -> dir/new.txt", got)
+	}
+}
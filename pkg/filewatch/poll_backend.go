@@ -0,0 +1,116 @@
+package filewatch
+
+import (
+	"os"
+	"time"
+
+	"github.com/radovskyb/watcher"
+)
+
+// pollWatcher adapts *watcher.Watcher (radovskyb/watcher) to the
+// Watcher interface. It polls the filesystem on an interval instead of
+// relying on native change notifications, so it works on NFS/FUSE
+// mounts and other filesystems fsnotify can't watch, and it isn't
+// subject to inotify/kqueue instance limits.
+type pollWatcher struct {
+	w        *watcher.Watcher
+	events   chan Event
+	errors   chan error
+	done     chan struct{}
+	interval time.Duration
+}
+
+func newPollWatcher(opts Options) (Watcher, error) {
+	w := watcher.New()
+	w.SetMaxEvents(1)
+	w.FilterOps(watcher.Create, watcher.Write, watcher.Remove, watcher.Rename, watcher.Move, watcher.Chmod)
+
+	if opts.SkipDir != nil {
+		skip := opts.SkipDir
+		w.AddFilterHook(func(info os.FileInfo, fullPath string) error {
+			if info.IsDir() && skip(info.Name()) {
+				return watcher.ErrSkip
+			}
+			return nil
+		})
+	}
+
+	pw := &pollWatcher{
+		w:        w,
+		events:   make(chan Event),
+		errors:   make(chan error),
+		done:     make(chan struct{}),
+		interval: opts.PollInterval,
+	}
+	go pw.loop()
+	go func() {
+		if err := pw.w.Start(pw.interval); err != nil {
+			select {
+			case pw.errors <- err:
+			case <-pw.done:
+			}
+		}
+	}()
+	return pw, nil
+}
+
+func (pw *pollWatcher) loop() {
+	for {
+		select {
+		case ev := <-pw.w.Event:
+			out := Event{Path: ev.Path, Op: translatePollOp(ev.Op), IsDir: ev.IsDir()}
+			if ev.Op == watcher.Rename || ev.Op == watcher.Move {
+				out.OldPath = ev.OldPath
+			}
+			select {
+			case pw.events <- out:
+			case <-pw.done:
+				return
+			}
+		case err := <-pw.w.Error:
+			select {
+			case pw.errors <- err:
+			case <-pw.done:
+				return
+			}
+		case <-pw.w.Closed:
+			return
+		case <-pw.done:
+			return
+		}
+	}
+}
+
+func translatePollOp(op watcher.Op) Op {
+	switch op {
+	case watcher.Create:
+		return Create
+	case watcher.Write:
+		return Write
+	case watcher.Remove:
+		return Remove
+	case watcher.Rename, watcher.Move:
+		return Rename
+	case watcher.Chmod:
+		return Chmod
+	default:
+		return 0
+	}
+}
+
+func (pw *pollWatcher) Events() <-chan Event { return pw.events }
+func (pw *pollWatcher) Errors() <-chan error { return pw.errors }
+
+func (pw *pollWatcher) Add(path string) error {
+	return pw.w.Add(path)
+}
+
+func (pw *pollWatcher) Remove(path string) error {
+	return pw.w.Remove(path)
+}
+
+func (pw *pollWatcher) Close() error {
+	close(pw.done)
+	pw.w.Close()
+	return nil
+}
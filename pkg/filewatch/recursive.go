@@ -0,0 +1,127 @@
+package filewatch
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// AddRecursive walks path with filepath.WalkDir and watches every
+// directory beneath it. path itself is remembered so that Create events
+// for new subdirectories discovered later can be registered automatically
+// by registerNewSubdir.
+func (fw *fsnotifyWatcher) AddRecursive(path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return ErrNotDirectory
+	}
+
+	err = filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if p != path && fw.skipDir != nil && fw.skipDir(d.Name()) {
+			return filepath.SkipDir
+		}
+		return fw.w.Add(p)
+	})
+	if err != nil {
+		return err
+	}
+
+	fw.mu.Lock()
+	fw.recursiveRoots = append(fw.recursiveRoots, path)
+	fw.mu.Unlock()
+	return nil
+}
+
+// RemoveRecursive stops watching path and every directory beneath it.
+func (fw *fsnotifyWatcher) RemoveRecursive(path string) error {
+	var firstErr error
+	_ = filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil //nolint:nilerr // keep walking past entries fsnotify never added
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if rmErr := fw.w.Remove(p); rmErr != nil && firstErr == nil {
+			firstErr = rmErr
+		}
+		return nil
+	})
+
+	fw.mu.Lock()
+	for i, root := range fw.recursiveRoots {
+		if root == path {
+			fw.recursiveRoots = append(fw.recursiveRoots[:i], fw.recursiveRoots[i+1:]...)
+			break
+		}
+	}
+	fw.mu.Unlock()
+
+	return firstErr
+}
+
+func (fw *fsnotifyWatcher) isUnderRecursiveRoot(path string) bool {
+	fw.mu.Lock()
+	defer fw.mu.Unlock()
+	for _, root := range fw.recursiveRoots {
+		if path == root || strings.HasPrefix(path, root+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
+
+// registerNewSubdir closes the race documented in filewatcher-v1.go's
+// step 5: between the moment a subdirectory is created and the moment
+// we call Add on it, files can already have appeared inside it. Without
+// this, the first events fsnotify reports for those files are Writes,
+// never the Create a caller would expect. We add the watch and then
+// synthesize a Create event for anything already sitting there.
+func (fw *fsnotifyWatcher) registerNewSubdir(dir string) {
+	if fw.skipDir != nil && fw.skipDir(filepath.Base(dir)) {
+		return
+	}
+	if err := fw.w.Add(dir); err != nil {
+		select {
+		case fw.errors <- err:
+		case <-fw.done:
+		}
+		return
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return
+	}
+	for _, entry := range entries {
+		childPath := filepath.Join(dir, entry.Name())
+		if entry.IsDir() {
+			fw.registerNewSubdir(childPath)
+		}
+		fw.emit(Event{Path: childPath, Op: Create, IsDir: entry.IsDir()})
+	}
+}
+
+// AddRecursive watches path and every directory beneath it, the way
+// watchDirectory did in filewatcher-v2.go. radovskyb/watcher's own
+// AddRecursive already walks the tree and, because it's poll-based,
+// naturally picks up files that existed before the watch was installed
+// on its first scan - there's no Create-vs-Write race to close here.
+func (pw *pollWatcher) AddRecursive(path string) error {
+	return pw.w.AddRecursive(path)
+}
+
+// RemoveRecursive stops watching path and everything beneath it.
+func (pw *pollWatcher) RemoveRecursive(path string) error {
+	return pw.w.RemoveRecursive(path)
+}
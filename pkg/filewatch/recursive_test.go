@@ -0,0 +1,54 @@
+package filewatch
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// TestFsnotifyWatcher_BackfillsRaceOnNewSubdir reproduces the race
+// filewatcher-v1.go's step 5 documents: a file can already exist inside
+// a brand-new subdirectory by the time we get around to watching it, so
+// without registerNewSubdir's backfill, fsnotify's first event for that
+// file would be a Write, never the Create a caller expects to see.
+func TestFsnotifyWatcher_BackfillsRaceOnNewSubdir(t *testing.T) {
+	root := t.TempDir()
+
+	w, err := New(Options{Backend: BackendFsnotify})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	defer w.Close()
+
+	if err := w.AddRecursive(root); err != nil {
+		t.Fatalf("AddRecursive: %v", err)
+	}
+
+	sub := filepath.Join(root, "sub")
+	if err := os.Mkdir(sub, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	// Write the file immediately, before the watcher has had any chance
+	// to process the subdirectory's own Create event and register a
+	// watch on it - the exact race registerNewSubdir exists to close.
+	file := filepath.Join(sub, "f.txt")
+	if err := os.WriteFile(file, []byte("x"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	deadline := time.After(2 * time.Second)
+	var sawFileCreate bool
+	for !sawFileCreate {
+		select {
+		case ev := <-w.Events():
+			if ev.Path == file && ev.Op&Create != 0 {
+				sawFileCreate = true
+			}
+		case err := <-w.Errors():
+			t.Fatalf("watcher error: %v", err)
+		case <-deadline:
+			t.Fatal("timed out waiting for a backfilled Create event for the new subdirectory's file")
+		}
+	}
+}
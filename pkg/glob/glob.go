@@ -0,0 +1,155 @@
+// Package glob lets a filewatch.Watcher be driven by a set of glob
+// patterns - e.g. --watch='./logs/**/*.log,./config/*.yaml'
+// --exclude='**/.git/**,**/node_modules/**' - instead of a single
+// directory and an allowed_extensions.txt file like filewatcher-v8.go
+// used.
+//
+// Validator pre-validates the patterns at startup, computes the minimal
+// set of directories a backend actually needs to watch, and answers
+// whether a given path should be forwarded once an event comes in.
+package glob
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/bmatcuk/doublestar/v4"
+)
+
+// defaultSkipDirs mirrors the walkAndWatch example: directories we
+// don't walk into (and therefore don't watch) unless a caller asks to.
+var defaultSkipDirs = map[string]struct{}{
+	".git":         {},
+	"node_modules": {},
+	"vendor":       {},
+	"build":        {},
+	"dist":         {},
+}
+
+// SkipDir reports whether a directory named name should be skipped
+// while computing a watch set: hidden directories (dotfiles, but not
+// "." itself) and the common build/vendor output dirs above.
+func SkipDir(name string) bool {
+	if name != "." && strings.HasPrefix(name, ".") {
+		return true
+	}
+	_, skip := defaultSkipDirs[name]
+	return skip
+}
+
+// Validator pre-validates a set of include/exclude glob patterns
+// (doublestar syntax - "**" matches across directory separators) and
+// decides whether a given path should be watched.
+type Validator struct {
+	include []string
+	exclude []string
+}
+
+// NewValidator builds a Validator from include/exclude patterns. It
+// rejects any include pattern whose static, non-wildcard prefix doesn't
+// resolve to an existing directory: a pattern like "./nope/**/*.log"
+// has nothing to watch if ./nope doesn't exist.
+func NewValidator(include, exclude []string) (*Validator, error) {
+	if len(include) == 0 {
+		return nil, fmt.Errorf("glob: at least one include pattern is required")
+	}
+
+	cleanInclude := make([]string, len(include))
+	for i, pat := range include {
+		if !doublestar.ValidatePattern(pat) {
+			return nil, fmt.Errorf("glob: invalid include pattern %q", pat)
+		}
+		base := staticPrefix(pat)
+		info, err := os.Stat(base)
+		if err != nil {
+			return nil, fmt.Errorf("glob: include pattern %q resolves to no existing parent directory (%s): %w", pat, base, err)
+		}
+		if !info.IsDir() {
+			return nil, fmt.Errorf("glob: include pattern %q's parent %s is not a directory", pat, base)
+		}
+		cleanInclude[i] = cleanPattern(pat)
+	}
+	cleanExclude := make([]string, len(exclude))
+	for i, pat := range exclude {
+		if !doublestar.ValidatePattern(pat) {
+			return nil, fmt.Errorf("glob: invalid exclude pattern %q", pat)
+		}
+		cleanExclude[i] = cleanPattern(pat)
+	}
+
+	return &Validator{include: cleanInclude, exclude: cleanExclude}, nil
+}
+
+// Dirs returns the minimal, deduplicated set of directories a backend
+// needs to register to be able to observe every include pattern: each
+// pattern's static (non-wildcard) prefix directory, leaving out any that
+// lies inside another, since watching the outer one recursively covers it.
+func (v *Validator) Dirs() []string {
+	seen := make(map[string]struct{})
+	var dirs []string
+	for _, pat := range v.include {
+		base := staticPrefix(pat)
+		if _, ok := seen[base]; ok {
+			continue
+		}
+		seen[base] = struct{}{}
+		dirs = append(dirs, base)
+	}
+	var minimal []string
+	for _, d := range dirs {
+		covered := false
+		for _, other := range dirs {
+			rel, err := filepath.Rel(other, d)
+			if other != d && err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+				covered = true
+			}
+		}
+		if !covered {
+			minimal = append(minimal, d)
+		}
+	}
+	return minimal
+}
+
+// Match reports whether path matches at least one include pattern and
+// no exclude pattern. Callers re-run this for every Create event before
+// forwarding it, since a new path might fall outside every pattern.
+func (v *Validator) Match(p string) bool {
+	p = cleanPattern(p)
+	for _, pat := range v.exclude {
+		if ok, _ := doublestar.Match(pat, p); ok {
+			return false
+		}
+	}
+	for _, pat := range v.include {
+		if ok, _ := doublestar.Match(pat, p); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// cleanPattern strips a leading "./" (and any other redundant "."/".."
+// segments) from a doublestar pattern, via the same rules path.Clean
+// applies to ordinary paths. doublestar.Match requires a literal match
+// between pattern and path, and filewatch backends report event paths
+// via filepath.Join, which Clean already strips "./" from - so without
+// this, a pattern like "./logs/**/*.log" would never match the
+// "logs/sub.log" paths events actually carry.
+func cleanPattern(pat string) string {
+	return path.Clean(pat)
+}
+
+// staticPrefix returns the directory doublestar would need to start
+// walking from before any wildcard in pat can match, i.e. everything
+// before the first path segment containing a glob metacharacter.
+func staticPrefix(pat string) string {
+	base, _ := doublestar.SplitPattern(pat)
+	if base == "" {
+		return "."
+	}
+	return base
+}
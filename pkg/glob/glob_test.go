@@ -0,0 +1,82 @@
+package glob
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestValidatorMatchDefaultWatchSpec reproduces a bug where the CLI's
+// own default --watch='./**/*' never matched anything: Dirs() watched
+// "." correctly, but Match() compared the still-"./"-prefixed pattern
+// against event paths that filepath.Join had already Cleaned, so every
+// event reported matched=false.
+func TestValidatorMatchDefaultWatchSpec(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.Mkdir(filepath.Join(dir, "logs"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	v, err := NewValidator([]string{"./**/*"}, nil)
+	if err != nil {
+		t.Fatalf("NewValidator: %v", err)
+	}
+
+	event := filepath.Join(dir, "logs", "app.log")
+	event = filepath.Clean(event)
+	if !v.Match(event) {
+		t.Errorf("Match(%q) = false, want true", event)
+	}
+}
+
+func TestValidatorMatchAbsolutePattern(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.Mkdir(filepath.Join(dir, "logs"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	v, err := NewValidator([]string{filepath.Join(dir, "logs") + "/**/*.log"}, nil)
+	if err != nil {
+		t.Fatalf("NewValidator: %v", err)
+	}
+
+	path := filepath.Clean(filepath.Join(dir, "logs", "app.log"))
+	if !v.Match(path) {
+		t.Errorf("Match(%q) = false, want true", path)
+	}
+}
+
+func TestValidatorMatchExclude(t *testing.T) {
+	dir := t.TempDir()
+
+	v, err := NewValidator([]string{"./**/*"}, []string{"**/.git/**"})
+	if err != nil {
+		t.Fatalf("NewValidator: %v", err)
+	}
+
+	path := filepath.Clean(filepath.Join(dir, ".git", "HEAD"))
+	if v.Match(path) {
+		t.Errorf("Match(%q) = true, want false (excluded)", path)
+	}
+}
+
+func TestNewValidatorRejectsMissingDir(t *testing.T) {
+	if _, err := NewValidator([]string{"./does-not-exist/**/*"}, nil); err == nil {
+		t.Error("NewValidator with nonexistent include dir = nil error, want error")
+	}
+}
+
+func TestSkipDir(t *testing.T) {
+	cases := map[string]bool{
+		".":            false,
+		".git":         true,
+		".hidden":      true,
+		"node_modules": true,
+		"src":          false,
+	}
+	for name, want := range cases {
+		if got := SkipDir(name); got != want {
+			t.Errorf("SkipDir(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+// Package ksum generalizes profiling's twoSum into the k-sum problem:
+// given nums and a target, find tuples of k distinct indices whose
+// values add up to target.
+package ksum
+
+// Options controls how Find searches.
+type Options struct {
+	// FirstOnly stops the search and returns after the first tuple is
+	// found, instead of collecting every one.
+	FirstOnly bool
+}
+
+// Find returns every tuple of k indices into nums whose values sum to
+// target (or just the first, per opts.FirstOnly), in ascending index
+// order within each tuple. k=2 is solved directly with the hashmap
+// approach profiling's twoSum uses; k>2 fixes nums[i] and recurses for
+// the remaining k-1 values against target-nums[i].
+func Find(nums []int, target, k int, opts Options) [][]int {
+	if k < 2 || k > len(nums) {
+		return nil
+	}
+	return find(nums, target, k, 0, opts.FirstOnly)
+}
+
+func find(nums []int, target, k, start int, firstOnly bool) [][]int {
+	if k == 2 {
+		return twoSumAll(nums, target, start, firstOnly)
+	}
+
+	var out [][]int
+	for i := start; i <= len(nums)-k; i++ {
+		for _, rest := range find(nums, target-nums[i], k-1, i+1, firstOnly) {
+			out = append(out, append([]int{i}, rest...))
+			if firstOnly {
+				return out
+			}
+		}
+	}
+	return out
+}
+
+// twoSumAll is profiling's twoSum generalized to return every matching
+// pair (or just the first, per firstOnly) among nums[start:], still
+// using a single pass with a value->indices hashmap so that repeated
+// values pair with every earlier occurrence.
+func twoSumAll(nums []int, target, start int, firstOnly bool) [][]int {
+	seen := make(map[int][]int) // value -> indices
+	var out [][]int
+	for i := start; i < len(nums); i++ {
+		complement := target - nums[i]
+		for _, j := range seen[complement] {
+			out = append(out, []int{j, i})
+			if firstOnly {
+				return out
+			}
+		}
+		seen[nums[i]] = append(seen[nums[i]], i)
+	}
+	return out
+}
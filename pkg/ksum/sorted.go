@@ -0,0 +1,30 @@
+package ksum
+
+import "sort"
+
+// twoSumSorted finds one pair of indices summing to target using the
+// sort-then-two-pointer approach, for comparison against twoSumAll's
+// hashmap in the benchmarks. It's O(n log n) instead of twoSumAll's
+// O(n), but does no map allocation, which matters at small n.
+func twoSumSorted(nums []int, target int) []int {
+	type entry struct{ val, idx int }
+	entries := make([]entry, len(nums))
+	for i, v := range nums {
+		entries[i] = entry{val: v, idx: i}
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].val < entries[j].val })
+
+	lo, hi := 0, len(entries)-1
+	for lo < hi {
+		sum := entries[lo].val + entries[hi].val
+		switch {
+		case sum == target:
+			return []int{entries[lo].idx, entries[hi].idx}
+		case sum < target:
+			lo++
+		default:
+			hi--
+		}
+	}
+	return nil
+}
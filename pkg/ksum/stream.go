@@ -0,0 +1,22 @@
+package ksum
+
+// Stream reports index pairs summing to target as they become
+// findable from an online sequence of values, rather than requiring
+// the whole slice up front the way Find does. The returned channel is
+// closed once in is drained.
+func Stream(in <-chan int, target int) <-chan [2]int {
+	out := make(chan [2]int)
+	go func() {
+		defer close(out)
+		seen := make(map[int]int) // value -> index
+		idx := 0
+		for v := range in {
+			if j, ok := seen[target-v]; ok {
+				out <- [2]int{j, idx}
+			}
+			seen[v] = idx
+			idx++
+		}
+	}()
+	return out
+}
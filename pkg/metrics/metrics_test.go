@@ -0,0 +1,34 @@
+package metrics
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestMetricsHandlerReportsObservations(t *testing.T) {
+	m := New()
+	m.ObserveEvent("CREATE", ".log")
+	m.ObserveEvent("CREATE", "")
+	m.ObservePolicyAction("quarantine")
+	m.IncQueueOverflow()
+	m.SetWatchedDirs(3)
+
+	rr := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/metrics", nil)
+	m.Handler().ServeHTTP(rr, req)
+
+	body := rr.Body.String()
+	cases := []string{
+		`filewatcher_events_total{op="CREATE"} 2`,
+		`filewatcher_extension_events_total{extension=".log"} 1`,
+		`filewatcher_policy_actions_total{action="quarantine"} 1`,
+		"filewatcher_queue_overflow_errors_total 1",
+		"filewatcher_watched_directories 3",
+	}
+	for _, want := range cases {
+		if !strings.Contains(body, want) {
+			t.Errorf("metrics output missing %q\nfull output:\n%s", want, body)
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package policy
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	"github.com/gen2brain/beeep"
+	"github.com/sirupsen/logrus"
+)
+
+// Engine applies a Policy's rules to files as they show up, the way
+// processEvents called handleDisallowedFile in filewatcher-v8.go.
+type Engine struct {
+	policy *Policy
+	logger *logrus.Logger
+	// DryRun logs what Handle would do without touching the file.
+	DryRun bool
+}
+
+// NewEngine builds an Engine that applies policy's rules, logging
+// through logger.
+func NewEngine(policy *Policy, logger *logrus.Logger) *Engine {
+	return &Engine{policy: policy, logger: logger}
+}
+
+// Handle looks up the first rule matching path and carries out its
+// action. A path matching no rule is left untouched.
+func (e *Engine) Handle(path string) error {
+	rule, err := e.policy.Match(path)
+	if err != nil {
+		return fmt.Errorf("policy: matching %s: %w", path, err)
+	}
+	if rule == nil {
+		return nil
+	}
+
+	if e.DryRun {
+		e.logger.Infof("[dry-run] rule %q matched %s, would %s it", rule.Name, path, rule.Action)
+		return nil
+	}
+
+	e.logger.Warnf("rule %q matched %s, taking action %s", rule.Name, path, rule.Action)
+	switch rule.Action {
+	case ActionLog:
+		return nil
+	case ActionNotify:
+		return beeep.Notify("Policy Alert", fmt.Sprintf("rule %q matched %s", rule.Name, path), "")
+	case ActionQuarantine:
+		return e.quarantine(path, *rule)
+	case ActionDelete:
+		return os.Remove(path)
+	case ActionExec:
+		return runExecAction(*rule, path)
+	default:
+		return fmt.Errorf("policy: rule %q has unknown action %q", rule.Name, rule.Action)
+	}
+}
+
+// quarantineRecord is the sidecar JSON written next to a quarantined file.
+type quarantineRecord struct {
+	OriginalPath  string    `json:"original_path"`
+	SHA256        string    `json:"sha256"`
+	Size          int64     `json:"size"`
+	ModTime       time.Time `json:"mod_time"`
+	Rule          string    `json:"rule"`
+	QuarantinedAt time.Time `json:"quarantined_at"`
+}
+
+// quarantine moves path into a timestamped .quarantine/ directory and
+// writes a sidecar JSON file recording its original path, hash, size,
+// mtime, and the rule that triggered the move.
+func (e *Engine) quarantine(path string, rule Rule) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+
+	sum, err := sha256File(path)
+	if err != nil {
+		return err
+	}
+
+	dir := e.policy.QuarantineDir
+	if dir == "" {
+		dir = ".quarantine"
+	}
+	dir = filepath.Join(dir, time.Now().UTC().Format("20060102T150405.000000000Z"))
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("policy: could not create quarantine dir: %w", err)
+	}
+
+	dest := filepath.Join(dir, filepath.Base(path))
+	if err := os.Rename(path, dest); err != nil {
+		return fmt.Errorf("policy: could not move %s to quarantine: %w", path, err)
+	}
+
+	record := quarantineRecord{
+		OriginalPath:  path,
+		SHA256:        sum,
+		Size:          info.Size(),
+		ModTime:       info.ModTime(),
+		Rule:          rule.Name,
+		QuarantinedAt: time.Now().UTC(),
+	}
+	sidecar, err := json.MarshalIndent(record, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(dest+".json", sidecar, 0o644)
+}
+
+func sha256File(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// runExecAction runs rule.ExecCommand directly (not through a shell, so
+// the matched path can't break out into shell metacharacters) with path
+// as its only argument.
+func runExecAction(rule Rule, path string) error {
+	if rule.ExecCommand == "" {
+		return fmt.Errorf("policy: rule %q has action exec but no exec_command", rule.Name)
+	}
+	cmd := exec.Command(rule.ExecCommand, path)
+	return cmd.Run()
+}
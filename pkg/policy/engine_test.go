@@ -0,0 +1,119 @@
+package policy
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+)
+
+func newTestEngine(p *Policy) *Engine {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	return NewEngine(p, logger)
+}
+
+func TestEngineHandleNoMatchLeavesFileAlone(t *testing.T) {
+	dir := t.TempDir()
+	target := filepath.Join(dir, "notes.txt")
+	if err := os.WriteFile(target, []byte("x"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	engine := newTestEngine(&Policy{Rules: []Rule{{Name: "exe-only", Extensions: []string{".exe"}, Action: ActionDelete}}})
+	if err := engine.Handle(target); err != nil {
+		t.Fatalf("Handle: %v", err)
+	}
+	if _, err := os.Stat(target); err != nil {
+		t.Errorf("file was touched despite no matching rule: %v", err)
+	}
+}
+
+func TestEngineHandleDelete(t *testing.T) {
+	dir := t.TempDir()
+	target := filepath.Join(dir, "payload.exe")
+	if err := os.WriteFile(target, []byte("x"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	engine := newTestEngine(&Policy{Rules: []Rule{{Name: "exe-only", Extensions: []string{".exe"}, Action: ActionDelete}}})
+	if err := engine.Handle(target); err != nil {
+		t.Fatalf("Handle: %v", err)
+	}
+	if _, err := os.Stat(target); !os.IsNotExist(err) {
+		t.Errorf("file still exists after delete action: %v", err)
+	}
+}
+
+func TestEngineHandleDryRunLeavesFileAlone(t *testing.T) {
+	dir := t.TempDir()
+	target := filepath.Join(dir, "payload.exe")
+	if err := os.WriteFile(target, []byte("x"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	engine := newTestEngine(&Policy{Rules: []Rule{{Name: "exe-only", Extensions: []string{".exe"}, Action: ActionDelete}}})
+	engine.DryRun = true
+	if err := engine.Handle(target); err != nil {
+		t.Fatalf("Handle: %v", err)
+	}
+	if _, err := os.Stat(target); err != nil {
+		t.Errorf("dry-run touched the file: %v", err)
+	}
+}
+
+func TestEngineHandleQuarantine(t *testing.T) {
+	dir := t.TempDir()
+	target := filepath.Join(dir, "payload.exe")
+	if err := os.WriteFile(target, []byte("danger"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	quarantineDir := filepath.Join(dir, ".quarantine")
+
+	engine := newTestEngine(&Policy{
+		QuarantineDir: quarantineDir,
+		Rules:         []Rule{{Name: "exe-only", Extensions: []string{".exe"}, Action: ActionQuarantine}},
+	})
+	if err := engine.Handle(target); err != nil {
+		t.Fatalf("Handle: %v", err)
+	}
+	if _, err := os.Stat(target); !os.IsNotExist(err) {
+		t.Errorf("original file still in place after quarantine: %v", err)
+	}
+
+	entries, err := os.ReadDir(quarantineDir)
+	if err != nil || len(entries) != 1 {
+		t.Fatalf("quarantine dir entries = %v, %v, want exactly one timestamped subdir", entries, err)
+	}
+	moved, err := os.ReadDir(filepath.Join(quarantineDir, entries[0].Name()))
+	if err != nil {
+		t.Fatalf("ReadDir quarantined subdir: %v", err)
+	}
+
+	var sawPayload, sawSidecar bool
+	for _, e := range moved {
+		switch e.Name() {
+		case "payload.exe":
+			sawPayload = true
+		case "payload.exe.json":
+			sawSidecar = true
+			data, err := os.ReadFile(filepath.Join(quarantineDir, entries[0].Name(), e.Name()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			var rec quarantineRecord
+			if err := json.Unmarshal(data, &rec); err != nil {
+				t.Fatalf("unmarshal sidecar: %v", err)
+			}
+			if rec.Rule != "exe-only" || rec.OriginalPath != target {
+				t.Errorf("sidecar record = %+v, want rule exe-only and original path %s", rec, target)
+			}
+		}
+	}
+	if !sawPayload || !sawSidecar {
+		t.Errorf("quarantined dir entries = %v, want payload.exe and payload.exe.json", moved)
+	}
+}
@@ -0,0 +1,191 @@
+// Package policy replaces filewatcher-v8.go's hard-coded
+// handleDisallowedFile (which just deleted anything with a disallowed
+// extension) with a set of rules loaded from a YAML file. Each rule
+// matches on extension, glob, size range and/or sniffed MIME type, and
+// names an Action to take - log, notify, quarantine, delete, or exec.
+package policy
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/bmatcuk/doublestar/v4"
+	"gopkg.in/yaml.v3"
+)
+
+// Action names what an Engine does with a file a Rule matched.
+type Action string
+
+const (
+	ActionLog        Action = "log"
+	ActionNotify     Action = "notify"
+	ActionQuarantine Action = "quarantine"
+	ActionDelete     Action = "delete"
+	ActionExec       Action = "exec"
+)
+
+// Rule describes one policy: every non-empty condition must match for
+// the rule to fire.
+type Rule struct {
+	Name string `yaml:"name"`
+
+	// Extensions, if set, restricts the rule to files whose extension
+	// (case-insensitive, with the leading dot, e.g. ".exe") is in the list.
+	Extensions []string `yaml:"extensions,omitempty"`
+	// Glob, if set, restricts the rule to paths matching the pattern.
+	Glob string `yaml:"glob,omitempty"`
+	// MinSize/MaxSize, if non-zero, restrict the rule to files whose
+	// size in bytes falls in [MinSize, MaxSize].
+	MinSize int64 `yaml:"min_size,omitempty"`
+	MaxSize int64 `yaml:"max_size,omitempty"`
+	// MIMETypes, if set, restricts the rule to files whose content,
+	// sniffed from the first 512 bytes via http.DetectContentType,
+	// matches one of these values - e.g. a ".txt" file that's actually
+	// "application/x-executable" still triggers.
+	MIMETypes []string `yaml:"mime_types,omitempty"`
+
+	Action Action `yaml:"action"`
+	// ExecCommand is run (not through a shell) with the matched file's
+	// path as its only argument when Action is "exec".
+	ExecCommand string `yaml:"exec_command,omitempty"`
+}
+
+func (r Rule) needsMIMESniff() bool { return len(r.MIMETypes) > 0 }
+
+// matches reports whether info/sniffedMIME satisfy every condition r sets.
+func (r Rule) matches(path string, info os.FileInfo, sniffedMIME string) (bool, error) {
+	if len(r.Extensions) > 0 && !hasExtension(path, r.Extensions) {
+		return false, nil
+	}
+	if r.Glob != "" {
+		ok, err := doublestar.Match(r.Glob, path)
+		if err != nil {
+			return false, fmt.Errorf("policy: rule %q: %w", r.Name, err)
+		}
+		if !ok {
+			return false, nil
+		}
+	}
+	if r.MinSize > 0 && info.Size() < r.MinSize {
+		return false, nil
+	}
+	if r.MaxSize > 0 && info.Size() > r.MaxSize {
+		return false, nil
+	}
+	if len(r.MIMETypes) > 0 && !matchesAny(sniffedMIME, r.MIMETypes) {
+		return false, nil
+	}
+	return true, nil
+}
+
+// Policy is an ordered list of rules; the first one that matches a file wins.
+type Policy struct {
+	Rules []Rule `yaml:"rules"`
+	// QuarantineDir is where ActionQuarantine moves files. Defaults to
+	// ".quarantine" relative to the process's working directory.
+	QuarantineDir string `yaml:"quarantine_dir,omitempty"`
+}
+
+// Load reads and parses a YAML policy file.
+func Load(path string) (*Policy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("policy: could not read policy file: %w", err)
+	}
+
+	var p Policy
+	if err := yaml.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("policy: could not parse policy file: %w", err)
+	}
+	for i, r := range p.Rules {
+		if r.Name == "" {
+			return nil, fmt.Errorf("policy: rule %d is missing a name", i)
+		}
+		if r.Action == "" {
+			return nil, fmt.Errorf("policy: rule %q is missing an action", r.Name)
+		}
+	}
+	return &p, nil
+}
+
+// Match returns the first rule that matches path, or nil if none do.
+func (p *Policy) Match(path string) (*Rule, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var sniffedMIME string
+	sniffed := false
+	for i := range p.Rules {
+		rule := p.Rules[i]
+		if rule.needsMIMESniff() && !sniffed {
+			sniffedMIME, err = sniffMIME(path)
+			if err != nil {
+				return nil, err
+			}
+			sniffed = true
+		}
+		ok, err := rule.matches(path, info, sniffedMIME)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			return &p.Rules[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// sniffMIME reads the first 512 bytes of path and returns the content
+// type http.DetectContentType infers from them, or
+// "application/x-executable" for an ELF header, which
+// http.DetectContentType does not recognize.
+func sniffMIME(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	buf := make([]byte, 512)
+	n, err := f.Read(buf)
+	if err != nil && n == 0 {
+		return "", err
+	}
+	if bytes.HasPrefix(buf[:n], []byte("\x7fELF")) {
+		return "application/x-executable", nil
+	}
+	return http.DetectContentType(buf[:n]), nil
+}
+
+func hasExtension(path string, exts []string) bool {
+	ext := extOf(path)
+	for _, want := range exts {
+		if ext == normalizeExt(want) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesAny(value string, candidates []string) bool {
+	for _, c := range candidates {
+		if value == c {
+			return true
+		}
+	}
+	return false
+}
+
+func extOf(path string) string {
+	return normalizeExt(filepath.Ext(path))
+}
+
+func normalizeExt(ext string) string {
+	return strings.ToLower(ext)
+}
@@ -0,0 +1,160 @@
+package policy
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writePolicy(t *testing.T, dir, yaml string) string {
+	t.Helper()
+	path := filepath.Join(dir, "policy.yaml")
+	if err := os.WriteFile(path, []byte(yaml), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadRejectsMissingNameAndAction(t *testing.T) {
+	dir := t.TempDir()
+
+	missingName := writePolicy(t, dir, "rules:\n  - action: log\n")
+	if _, err := Load(missingName); err == nil {
+		t.Error("Load with unnamed rule = nil error, want error")
+	}
+
+	missingAction := writePolicy(t, dir, "rules:\n  - name: r1\n")
+	if _, err := Load(missingAction); err == nil {
+		t.Error("Load with actionless rule = nil error, want error")
+	}
+}
+
+func TestPolicyMatchByExtension(t *testing.T) {
+	dir := t.TempDir()
+	target := filepath.Join(dir, "payload.exe")
+	if err := os.WriteFile(target, []byte("x"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	path := writePolicy(t, dir, `
+rules:
+  - name: block-exe
+    extensions: [".exe"]
+    action: delete
+`)
+	p, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	rule, err := p.Match(target)
+	if err != nil {
+		t.Fatalf("Match: %v", err)
+	}
+	if rule == nil || rule.Name != "block-exe" {
+		t.Errorf("Match = %v, want rule block-exe", rule)
+	}
+}
+
+func TestPolicyMatchNoRuleMatches(t *testing.T) {
+	dir := t.TempDir()
+	target := filepath.Join(dir, "notes.txt")
+	if err := os.WriteFile(target, []byte("x"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	path := writePolicy(t, dir, `
+rules:
+  - name: block-exe
+    extensions: [".exe"]
+    action: delete
+`)
+	p, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	rule, err := p.Match(target)
+	if err != nil {
+		t.Fatalf("Match: %v", err)
+	}
+	if rule != nil {
+		t.Errorf("Match = %v, want nil", rule)
+	}
+}
+
+func TestPolicyMatchBySizeRange(t *testing.T) {
+	dir := t.TempDir()
+	small := filepath.Join(dir, "small.bin")
+	if err := os.WriteFile(small, []byte("x"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	big := filepath.Join(dir, "big.bin")
+	if err := os.WriteFile(big, make([]byte, 1024), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	path := writePolicy(t, dir, `
+rules:
+  - name: big-files
+    min_size: 100
+    action: log
+`)
+	p, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	if rule, err := p.Match(small); err != nil || rule != nil {
+		t.Errorf("Match(small) = %v, %v, want nil, nil", rule, err)
+	}
+	if rule, err := p.Match(big); err != nil || rule == nil {
+		t.Errorf("Match(big) = %v, %v, want non-nil rule, nil error", rule, err)
+	}
+}
+
+// TestPolicyMatchBySniffedMIME checks that a rule's mime_types condition
+// fires off the file's actual sniffed content, not its extension: a
+// ".txt" file that's really a gzip archive (magic bytes \x1F\x8B\x08)
+// still triggers the mime_types rule. http.DetectContentType doesn't
+// recognize ELF/PE executables, so gzip is the closest disguised-binary
+// case it can actually sniff.
+func TestPolicyMatchBySniffedMIME(t *testing.T) {
+	dir := t.TempDir()
+	disguised := filepath.Join(dir, "notes.txt")
+	gzipMagic := []byte{0x1F, 0x8B, 0x08, 0, 0, 0, 0, 0}
+	if err := os.WriteFile(disguised, gzipMagic, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	plain := filepath.Join(dir, "plain.txt")
+	if err := os.WriteFile(plain, []byte("just text"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	path := writePolicy(t, dir, `
+rules:
+  - name: disguised-archive
+    mime_types: ["application/x-gzip"]
+    action: log
+`)
+	p, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	rule, err := p.Match(disguised)
+	if err != nil {
+		t.Fatalf("Match(disguised): %v", err)
+	}
+	if rule == nil || rule.Name != "disguised-archive" {
+		t.Errorf("Match(disguised) = %v, want rule disguised-archive", rule)
+	}
+
+	rule, err = p.Match(plain)
+	if err != nil {
+		t.Fatalf("Match(plain): %v", err)
+	}
+	if rule != nil {
+		t.Errorf("Match(plain) = %v, want nil", rule)
+	}
+}